@@ -0,0 +1,917 @@
+package jsonq
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Filter is a single stage of a jq-style pipeline. It receives a stream of
+// input values and produces a stream of output values, mirroring jq's model
+// where every expression maps a sequence of inputs to a sequence of outputs.
+type Filter interface {
+	Apply(ctx []interface{}) ([]interface{}, error)
+}
+
+// Eval evaluates a jq-style expression against the JsonQuery's blob and
+// returns every value the expression produces. Supported syntax includes
+// identity (.), field access (.foo.bar), indexing (.a[0]), slicing
+// (.a[2:5]), iteration (.a[]), recursive descent (..), pipes (|), comma
+// for multiple outputs (,), and select(<predicate>) with the usual
+// comparison and boolean operators.
+func (j *JsonQuery) Eval(expr string) ([]interface{}, error) {
+	f, err := compileFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	return f.Apply([]interface{}{j.blob})
+}
+
+// EvalOne evaluates expr like Eval, but requires the expression to produce
+// exactly one value and returns it directly.
+func (j *JsonQuery) EvalOne(expr string) (interface{}, error) {
+	vals, err := j.Eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) != 1 {
+		return nil, fmt.Errorf("Eval(%q) produced %d values, expected exactly one\n", expr, len(vals))
+	}
+	return vals[0], nil
+}
+
+// compileFilter lexes and parses a jq-style expression into a Filter.
+func compileFilter(expr string) (Filter, error) {
+	toks, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{toks: toks}
+	f, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("unexpected token %q in expression %q\n", p.peek().text, expr)
+	}
+	return f, nil
+}
+
+// --- lexer -----------------------------------------------------------------
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokDot
+	tokDotDot
+	tokLBracket
+	tokRBracket
+	tokLParen
+	tokRParen
+	tokColon
+	tokComma
+	tokPipe
+	tokIdent
+	tokNumber
+	tokString
+	tokEq
+	tokNe
+	tokLt
+	tokLe
+	tokGt
+	tokGe
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lexFilter tokenizes a jq-style expression.
+func lexFilter(expr string) ([]token, error) {
+	var toks []token
+	r := []rune(expr)
+	i, n := 0, len(r)
+	for i < n {
+		c := r[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '.' && i+1 < n && r[i+1] == '.':
+			toks = append(toks, token{tokDotDot, ".."})
+			i += 2
+		case c == '.':
+			toks = append(toks, token{tokDot, "."})
+			i++
+		case c == '[':
+			toks = append(toks, token{tokLBracket, "["})
+			i++
+		case c == ']':
+			toks = append(toks, token{tokRBracket, "]"})
+			i++
+		case c == '(':
+			toks = append(toks, token{tokLParen, "("})
+			i++
+		case c == ')':
+			toks = append(toks, token{tokRParen, ")"})
+			i++
+		case c == ':':
+			toks = append(toks, token{tokColon, ":"})
+			i++
+		case c == ',':
+			toks = append(toks, token{tokComma, ","})
+			i++
+		case c == '|':
+			toks = append(toks, token{tokPipe, "|"})
+			i++
+		case c == '=' && i+1 < n && r[i+1] == '=':
+			toks = append(toks, token{tokEq, "=="})
+			i += 2
+		case c == '!' && i+1 < n && r[i+1] == '=':
+			toks = append(toks, token{tokNe, "!="})
+			i += 2
+		case c == '<' && i+1 < n && r[i+1] == '=':
+			toks = append(toks, token{tokLe, "<="})
+			i += 2
+		case c == '>' && i+1 < n && r[i+1] == '=':
+			toks = append(toks, token{tokGe, ">="})
+			i += 2
+		case c == '<':
+			toks = append(toks, token{tokLt, "<"})
+			i++
+		case c == '>':
+			toks = append(toks, token{tokGt, ">"})
+			i++
+		case c == '"':
+			j := i + 1
+			for j < n && r[j] != '"' {
+				j++
+			}
+			if j >= n {
+				return nil, fmt.Errorf("unterminated string literal in expression %q\n", expr)
+			}
+			toks = append(toks, token{tokString, string(r[i+1 : j])})
+			i = j + 1
+		case c == '-' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < n {
+				if r[j] >= '0' && r[j] <= '9' {
+					j++
+					continue
+				}
+				// Only consume "." as a decimal point, not as the path
+				// separator that may immediately follow an integer (e.g.
+				// the "0" in "users.0.name").
+				if r[j] == '.' && j+1 < n && r[j+1] >= '0' && r[j+1] <= '9' {
+					j++
+					continue
+				}
+				break
+			}
+			toks = append(toks, token{tokNumber, string(r[i:j])})
+			i = j
+		case isIdentStart(c):
+			j := i + 1
+			for j < n && isIdentPart(r[j]) {
+				j++
+			}
+			toks = append(toks, token{tokIdent, string(r[i:j])})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q in expression %q\n", string(c), expr)
+		}
+	}
+	toks = append(toks, token{tokEOF, ""})
+	return toks, nil
+}
+
+func isIdentStart(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c rune) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// --- filter parser (Pratt-style, precedence climbing) -----------------------
+
+type filterParser struct {
+	toks []token
+	pos  int
+}
+
+func (p *filterParser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *filterParser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) expect(k tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != k {
+		return t, fmt.Errorf("expected %s, got %q\n", what, t.text)
+	}
+	return t, nil
+}
+
+// parsePipe handles the lowest-precedence "|" operator: left | right.
+func (p *filterParser) parsePipe() (Filter, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokPipe {
+		p.next()
+		right, err := p.parseComma()
+		if err != nil {
+			return nil, err
+		}
+		left = &pipeFilter{left, right}
+	}
+	return left, nil
+}
+
+// parseComma handles "," which fans a single input out to multiple outputs.
+func (p *filterParser) parseComma() (Filter, error) {
+	first, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	filters := []Filter{first}
+	for p.peek().kind == tokComma {
+		p.next()
+		next, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, next)
+	}
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return &commaFilter{filters}, nil
+}
+
+// parsePostfix parses a primary filter followed by any chained .field,
+// [index]/[slice]/[] suffixes.
+func (p *filterParser) parsePostfix() (Filter, error) {
+	f, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokDot:
+			p.next()
+			name, err := p.expect(tokIdent, "field name")
+			if err != nil {
+				return nil, err
+			}
+			f = &pipeFilter{f, &fieldFilter{name.text}}
+		case tokLBracket:
+			bf, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			f = &pipeFilter{f, bf}
+		default:
+			return f, nil
+		}
+	}
+}
+
+// parseBracket parses "[]" (iterate), "[N]" (index) or "[A:B]" (slice).
+func (p *filterParser) parseBracket() (Filter, error) {
+	p.next() // consume '['
+	if p.peek().kind == tokRBracket {
+		p.next()
+		return &iterateFilter{}, nil
+	}
+	var from, to *int
+	if p.peek().kind != tokColon {
+		n, err := p.parseIntLiteral()
+		if err != nil {
+			return nil, err
+		}
+		from = &n
+	}
+	if p.peek().kind == tokColon {
+		p.next()
+		if p.peek().kind != tokRBracket {
+			n, err := p.parseIntLiteral()
+			if err != nil {
+				return nil, err
+			}
+			to = &n
+		}
+		if _, err := p.expect(tokRBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &sliceFilter{from, to}, nil
+	}
+	if _, err := p.expect(tokRBracket, "]"); err != nil {
+		return nil, err
+	}
+	return &indexFilter{*from}, nil
+}
+
+func (p *filterParser) parseIntLiteral() (int, error) {
+	t, err := p.expect(tokNumber, "integer")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(t.text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid array index %q\n", t.text)
+	}
+	return n, nil
+}
+
+// parsePrimary parses ".", "..", "select(...)", "(" pipe ")" and legacy
+// dotted-path literals reused from rquery for backward compatibility.
+func (p *filterParser) parsePrimary() (Filter, error) {
+	switch p.peek().kind {
+	case tokDotDot:
+		p.next()
+		return &recurseFilter{}, nil
+	case tokDot:
+		p.next()
+		switch p.peek().kind {
+		case tokIdent:
+			return &fieldFilter{p.next().text}, nil
+		case tokLBracket:
+			return p.parseBracket()
+		default:
+			return &identityFilter{}, nil
+		}
+	case tokLParen:
+		p.next()
+		f, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case tokIdent:
+		if p.peek().text == "select" {
+			p.next()
+			if _, err := p.expect(tokLParen, "("); err != nil {
+				return nil, err
+			}
+			cond, err := p.parseOrExpr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokRParen, ")"); err != nil {
+				return nil, err
+			}
+			return &selectFilter{cond}, nil
+		}
+		// A bareword path with no leading "." (e.g. "foo.bar.0") is the
+		// legacy rquery/Get syntax; reuse it as-is for backward compatibility.
+		segs := []string{p.next().text}
+		for p.peek().kind == tokDot {
+			p.next()
+			nt := p.peek()
+			if nt.kind != tokIdent && nt.kind != tokNumber {
+				return nil, fmt.Errorf("expected path segment, got %q\n", nt.text)
+			}
+			p.next()
+			segs = append(segs, nt.text)
+		}
+		return &legacyFilter{segs}, nil
+	}
+	return nil, fmt.Errorf("unexpected token %q while parsing filter\n", p.peek().text)
+}
+
+// --- select() predicate expressions -----------------------------------------
+
+// expr evaluates a boolean/value predicate against a single jq value.
+type expr interface {
+	eval(val interface{}) (interface{}, error)
+}
+
+func (p *filterParser) parseOrExpr() (expr, error) {
+	left, err := p.parseAndExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokIdent && p.peek().text == "or" {
+		p.next()
+		right, err := p.parseAndExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{"or", left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAndExpr() (expr, error) {
+	left, err := p.parseNotExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokIdent && p.peek().text == "and" {
+		p.next()
+		right, err := p.parseNotExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{"and", left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNotExpr() (expr, error) {
+	if p.peek().kind == tokIdent && p.peek().text == "not" {
+		p.next()
+		inner, err := p.parseNotExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{inner}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (expr, error) {
+	left, err := p.parseExprPrimary()
+	if err != nil {
+		return nil, err
+	}
+	op := ""
+	switch p.peek().kind {
+	case tokEq:
+		op = "=="
+	case tokNe:
+		op = "!="
+	case tokLt:
+		op = "<"
+	case tokLe:
+		op = "<="
+	case tokGt:
+		op = ">"
+	case tokGe:
+		op = ">="
+	default:
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseExprPrimary()
+	if err != nil {
+		return nil, err
+	}
+	return &compareExpr{op, left, right}, nil
+}
+
+func (p *filterParser) parseExprPrimary() (expr, error) {
+	switch p.peek().kind {
+	case tokLParen:
+		p.next()
+		e, err := p.parseOrExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case tokDot:
+		p.next()
+		var segs []string
+		for p.peek().kind == tokIdent {
+			segs = append(segs, p.next().text)
+			if p.peek().kind == tokDot {
+				p.next()
+				continue
+			}
+			break
+		}
+		return &fieldExpr{segs}, nil
+	case tokNumber:
+		t := p.next()
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number literal %q\n", t.text)
+		}
+		return &literalExpr{f}, nil
+	case tokString:
+		t := p.next()
+		return &literalExpr{t.text}, nil
+	case tokIdent:
+		switch p.peek().text {
+		case "true":
+			p.next()
+			return &literalExpr{true}, nil
+		case "false":
+			p.next()
+			return &literalExpr{false}, nil
+		case "null":
+			p.next()
+			return &literalExpr{nil}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected token %q while parsing select() predicate\n", p.peek().text)
+}
+
+// --- Filter implementations --------------------------------------------------
+
+// identityFilter is jq's ".": it passes every input through unchanged.
+type identityFilter struct{}
+
+func (f *identityFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	return ctx, nil
+}
+
+// pipeFilter feeds the output of left into right, like jq's "|".
+type pipeFilter struct {
+	left, right Filter
+}
+
+func (f *pipeFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	mid, err := f.left.Apply(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return f.right.Apply(mid)
+}
+
+// commaFilter runs every sub-filter against the same input and concatenates
+// their outputs, like jq's ",".
+type commaFilter struct {
+	filters []Filter
+}
+
+func (f *commaFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	var out []interface{}
+	for _, sub := range f.filters {
+		vals, err := sub.Apply(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vals...)
+	}
+	return out, nil
+}
+
+// fieldFilter looks up a single named field on each input object.
+type fieldFilter struct {
+	name string
+}
+
+func (f *fieldFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, 0, len(ctx))
+	for _, val := range ctx {
+		if val == nil {
+			out = append(out, nil)
+			continue
+		}
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Cannot index %T with \"%s\"\n", val, f.name)
+		}
+		out = append(out, obj[f.name])
+	}
+	return out, nil
+}
+
+// indexFilter looks up a single array index on each input, supporting
+// negative indices. A null input yields null; any other non-array input is
+// an error.
+type indexFilter struct {
+	index int
+}
+
+func (f *indexFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, 0, len(ctx))
+	for _, val := range ctx {
+		if val == nil {
+			out = append(out, nil)
+			continue
+		}
+		arr, ok := val.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Cannot index %T with number\n", val)
+		}
+		idx := f.index
+		if idx < 0 {
+			idx += len(arr)
+		}
+		if idx < 0 || idx >= len(arr) {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, arr[idx])
+	}
+	return out, nil
+}
+
+// sliceFilter implements jq's ".a[from:to]" array slicing, clamping bounds
+// the same way jq does rather than erroring on out-of-range indices.
+type sliceFilter struct {
+	from, to *int
+}
+
+func (f *sliceFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, 0, len(ctx))
+	for _, val := range ctx {
+		if val == nil {
+			out = append(out, nil)
+			continue
+		}
+		arr, ok := val.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Cannot slice %T\n", val)
+		}
+		from, to := 0, len(arr)
+		if f.from != nil {
+			from = clampIndex(*f.from, len(arr))
+		}
+		if f.to != nil {
+			to = clampIndex(*f.to, len(arr))
+		}
+		if from > to {
+			from = to
+		}
+		out = append(out, arr[from:to])
+	}
+	return out, nil
+}
+
+func clampIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+// iterateFilter implements ".[]": it fans each array or object input out
+// into its elements/values.
+type iterateFilter struct{}
+
+func (f *iterateFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	var out []interface{}
+	for _, val := range ctx {
+		switch v := val.(type) {
+		case []interface{}:
+			out = append(out, v...)
+		case map[string]interface{}:
+			for _, item := range v {
+				out = append(out, item)
+			}
+		default:
+			return nil, fmt.Errorf("Cannot iterate over %T (%v)\n", val, val)
+		}
+	}
+	return out, nil
+}
+
+// recurseFilter implements "..": a depth-first pre-order walk emitting every
+// value reachable from the input, including the input itself.
+type recurseFilter struct{}
+
+func (f *recurseFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	var out []interface{}
+	for _, val := range ctx {
+		collectRecursive(val, &out)
+	}
+	return out, nil
+}
+
+func collectRecursive(val interface{}, out *[]interface{}) {
+	*out = append(*out, val)
+	switch v := val.(type) {
+	case []interface{}:
+		for _, item := range v {
+			collectRecursive(item, out)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectRecursive(item, out)
+		}
+	}
+}
+
+// selectFilter implements "select(cond)": it keeps only the inputs for
+// which cond evaluates truthy.
+type selectFilter struct {
+	cond expr
+}
+
+func (f *selectFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	var out []interface{}
+	for _, val := range ctx {
+		res, err := f.cond.eval(val)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(res) {
+			out = append(out, val)
+		}
+	}
+	return out, nil
+}
+
+func truthy(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
+}
+
+// legacyFilter reuses the existing positional "a.b.c" path syntax as a
+// filter, so Eval stays compatible with plain paths as well as jq syntax.
+type legacyFilter struct {
+	path []string
+}
+
+func (f *legacyFilter) Apply(ctx []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, 0, len(ctx))
+	for _, val := range ctx {
+		res, err := rquery(val, f.path...)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, res)
+	}
+	return out, nil
+}
+
+// --- expr implementations ----------------------------------------------------
+
+// fieldExpr reads a (possibly nested) field off the value under test inside
+// a select() predicate, e.g. the ".age" in "select(.age > 30)".
+type fieldExpr struct {
+	segments []string
+}
+
+func (e *fieldExpr) eval(val interface{}) (interface{}, error) {
+	cur := val
+	for _, seg := range e.segments {
+		if cur == nil {
+			return nil, nil
+		}
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Cannot index %T with \"%s\"\n", cur, seg)
+		}
+		cur = obj[seg]
+	}
+	if len(e.segments) == 0 {
+		return val, nil
+	}
+	return cur, nil
+}
+
+// literalExpr is a constant number, string, bool or null in a predicate.
+type literalExpr struct {
+	value interface{}
+}
+
+func (e *literalExpr) eval(val interface{}) (interface{}, error) {
+	return e.value, nil
+}
+
+// notExpr negates a predicate.
+type notExpr struct {
+	inner expr
+}
+
+func (e *notExpr) eval(val interface{}) (interface{}, error) {
+	res, err := e.inner.eval(val)
+	if err != nil {
+		return nil, err
+	}
+	return !truthy(res), nil
+}
+
+// logicalExpr implements "and"/"or" with short-circuit evaluation.
+type logicalExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *logicalExpr) eval(val interface{}) (interface{}, error) {
+	l, err := e.left.eval(val)
+	if err != nil {
+		return nil, err
+	}
+	if e.op == "and" && !truthy(l) {
+		return false, nil
+	}
+	if e.op == "or" && truthy(l) {
+		return true, nil
+	}
+	r, err := e.right.eval(val)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(r), nil
+}
+
+// compareExpr implements ==, !=, <, <=, >, >= between two sub-expressions.
+type compareExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *compareExpr) eval(val interface{}) (interface{}, error) {
+	l, err := e.left.eval(val)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.right.eval(val)
+	if err != nil {
+		return nil, err
+	}
+	return compareValues(e.op, l, r)
+}
+
+func compareValues(op string, l, r interface{}) (bool, error) {
+	if lf, lerr := floatFromInterface(l); lerr == nil {
+		if rf, rerr := floatFromInterface(r); rerr == nil {
+			return compareOrdered(op, floatCompare(lf, rf))
+		}
+	}
+	if ls, ok := l.(string); ok {
+		if rs, ok := r.(string); ok {
+			return compareOrdered(op, strings.Compare(ls, rs))
+		}
+	}
+	switch op {
+	case "==":
+		if !comparable(l) || !comparable(r) {
+			return reflect.DeepEqual(l, r), nil
+		}
+		return l == r, nil
+	case "!=":
+		if !comparable(l) || !comparable(r) {
+			return !reflect.DeepEqual(l, r), nil
+		}
+		return l != r, nil
+	}
+	return false, fmt.Errorf("cannot compare %T and %T with %q\n", l, r, op)
+}
+
+// comparable reports whether val is safe to pass to Go's "==" directly.
+// Decoded JSON objects and arrays are maps/slices, which panic on "=="; we
+// fall back to reflect.DeepEqual for those instead.
+func comparable(val interface{}) bool {
+	switch val.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// floatCompare returns -1, 0 or 1 as a is less than, equal to or greater
+// than b, mirroring the semantics of strings.Compare for numeric values.
+func floatCompare(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareOrdered(op string, cmp int) (bool, error) {
+	switch op {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+	return false, fmt.Errorf("unknown comparison operator %q\n", op)
+}
@@ -0,0 +1,146 @@
+package jsonq
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, doc string) *JsonQuery {
+	t.Helper()
+	q, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", doc, err)
+	}
+	return q
+}
+
+func TestEvalIdentityAndFieldAccess(t *testing.T) {
+	q := mustParse(t, `{"a":{"b":42}}`)
+
+	if vals, err := q.Eval("."); err != nil || len(vals) != 1 {
+		t.Fatalf("Eval(.) = %v, %v", vals, err)
+	}
+
+	v, err := q.EvalOne(".a.b")
+	if err != nil {
+		t.Fatalf("EvalOne(.a.b): %v", err)
+	}
+	if v != float64(42) {
+		t.Fatalf("EvalOne(.a.b) = %v, want 42", v)
+	}
+}
+
+func TestEvalIndexAndSlice(t *testing.T) {
+	q := mustParse(t, `{"a":[0,1,2,3,4,5]}`)
+
+	v, err := q.EvalOne(".a[2]")
+	if err != nil || v != float64(2) {
+		t.Fatalf("EvalOne(.a[2]) = %v, %v", v, err)
+	}
+
+	vals, err := q.Eval(".a[2:4]")
+	if err != nil {
+		t.Fatalf("Eval(.a[2:4]): %v", err)
+	}
+	want := []interface{}{float64(2), float64(3)}
+	if len(vals) != 1 || !reflect.DeepEqual(vals[0], want) {
+		t.Fatalf("Eval(.a[2:4]) = %v, want [%v]", vals, want)
+	}
+}
+
+func TestEvalIterateAndRecursiveDescent(t *testing.T) {
+	q := mustParse(t, `{"users":[{"name":"alice"},{"name":"bob"}]}`)
+
+	names, err := q.Eval(".users[].name")
+	if err != nil {
+		t.Fatalf("Eval(.users[].name): %v", err)
+	}
+	if !reflect.DeepEqual(names, []interface{}{"alice", "bob"}) {
+		t.Fatalf("Eval(.users[].name) = %v", names)
+	}
+
+	q2 := mustParse(t, `{"meta":{"error_code":"E1","nested":{"error_code":"E2"}}}`)
+	codes, err := q2.Eval("..|select(. == \"E1\" or . == \"E2\")")
+	if err != nil {
+		t.Fatalf("Eval(recursive descent): %v", err)
+	}
+	if len(codes) != 2 {
+		t.Fatalf("Eval(recursive descent) = %v, want 2 matches", codes)
+	}
+}
+
+func TestEvalPipeAndComma(t *testing.T) {
+	q := mustParse(t, `{"a":1,"b":2}`)
+
+	vals, err := q.Eval(".a, .b")
+	if err != nil {
+		t.Fatalf("Eval(.a, .b): %v", err)
+	}
+	if !reflect.DeepEqual(vals, []interface{}{float64(1), float64(2)}) {
+		t.Fatalf("Eval(.a, .b) = %v", vals)
+	}
+
+	v, err := q.EvalOne(".a | . ")
+	if err != nil || v != float64(1) {
+		t.Fatalf("Eval(.a | .) = %v, %v", v, err)
+	}
+}
+
+func TestEvalSelectComparisonsAndBooleans(t *testing.T) {
+	q := mustParse(t, `{"users":[{"name":"alice","age":40},{"name":"bob","age":20}]}`)
+
+	vals, err := q.Eval(".users[] | select(.age > 30)")
+	if err != nil {
+		t.Fatalf("Eval select: %v", err)
+	}
+	if len(vals) != 1 {
+		t.Fatalf("Eval select = %v, want 1 match", vals)
+	}
+	got := vals[0].(map[string]interface{})
+	if got["name"] != "alice" {
+		t.Fatalf("Eval select matched %v, want alice", got)
+	}
+
+	vals, err = q.Eval(".users[] | select(.age > 10 and .age < 30)")
+	if err != nil || len(vals) != 1 {
+		t.Fatalf("Eval select and: %v, %v", vals, err)
+	}
+
+	vals, err = q.Eval(".users[] | select(not (.age > 30))")
+	if err != nil || len(vals) != 1 {
+		t.Fatalf("Eval select not: %v, %v", vals, err)
+	}
+}
+
+func TestEvalLegacyBarewordPath(t *testing.T) {
+	q := mustParse(t, `{"users":[{"name":"alice"},{"name":"bob"}]}`)
+
+	v, err := q.EvalOne("users.0.name")
+	if err != nil {
+		t.Fatalf("EvalOne(users.0.name): %v", err)
+	}
+	if v != "alice" {
+		t.Fatalf("EvalOne(users.0.name) = %v, want alice", v)
+	}
+}
+
+func TestEvalSelectEqualityOnObjectsDoesNotPanic(t *testing.T) {
+	q := mustParse(t, `{"a":{"x":1},"b":{"x":1},"c":{"x":2}}`)
+
+	vals, err := q.Eval("select(.a == .b)")
+	if err != nil {
+		t.Fatalf("Eval select(.a == .b): %v", err)
+	}
+	if len(vals) != 1 {
+		t.Fatalf("Eval select(.a == .b) = %v, want one match (identical objects)", vals)
+	}
+
+	vals, err = q.Eval("select(.a == .c)")
+	if err != nil {
+		t.Fatalf("Eval select(.a == .c): %v", err)
+	}
+	if len(vals) != 0 {
+		t.Fatalf("Eval select(.a == .c) = %v, want no match (different objects)", vals)
+	}
+}
@@ -0,0 +1,98 @@
+package jsonq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind identifies the expected Go type for a Target passed to Extract.
+type Kind int
+
+// Supported Target kinds.
+const (
+	KindString Kind = iota
+	KindInt
+	KindFloat
+	KindBool
+	KindObject
+	KindArray
+	KindInterface
+)
+
+// Target describes one value to pull out of a JsonQuery: where it lives
+// and what type it is expected to have.
+type Target struct {
+	Path string
+	Kind Kind
+}
+
+// Result holds the values successfully extracted by Extract, keyed by the
+// same name used in the spec passed to it.
+type Result map[string]interface{}
+
+// ExtractError records a single Target that failed to extract, alongside
+// the underlying error: either the path lookup failure or the
+// type-conversion error from stringFromInterface, intFromInterface, etc.
+type ExtractError struct {
+	Name string
+	Path string
+	Err  error
+}
+
+func (e *ExtractError) Error() string {
+	return fmt.Sprintf("field %q at path %q: %v", e.Name, e.Path, e.Err)
+}
+
+// ErrorList collects every ExtractError produced by a single Extract call.
+type ErrorList []*ExtractError
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Extract pulls every Target in spec out of j in a single pass. Unlike
+// Int/String/Object/etc., a Target that fails to extract does not stop the
+// others: every value that succeeds ends up in Result, and every failure
+// is collected into the returned ErrorList (nil if everything succeeded).
+// This suits mixed-shape API responses where validating every field
+// up front matters more than bailing on the first missing one.
+func (j *JsonQuery) Extract(spec map[string]Target) (Result, ErrorList) {
+	result := make(Result, len(spec))
+	var errs ErrorList
+	for name, target := range spec {
+		val, err := extractOne(j, target)
+		if err != nil {
+			errs = append(errs, &ExtractError{Name: name, Path: target.Path, Err: err})
+			continue
+		}
+		result[name] = val
+	}
+	if len(errs) == 0 {
+		return result, nil
+	}
+	return result, errs
+}
+
+func extractOne(j *JsonQuery, t Target) (interface{}, error) {
+	switch t.Kind {
+	case KindString:
+		return j.String(t.Path)
+	case KindInt:
+		return j.Int64(t.Path)
+	case KindFloat:
+		return j.Float(t.Path)
+	case KindBool:
+		return j.Bool(t.Path)
+	case KindObject:
+		return j.Object(t.Path)
+	case KindArray:
+		return j.Array(t.Path)
+	case KindInterface:
+		return j.Interface(t.Path)
+	}
+	return nil, fmt.Errorf("unknown Kind %d for path %q\n", t.Kind, t.Path)
+}
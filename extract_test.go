@@ -0,0 +1,67 @@
+package jsonq
+
+import "testing"
+
+func TestExtractCollectsSuccessesAndFailures(t *testing.T) {
+	q := mustParse(t, `{"name":"alice","age":40,"active":true}`)
+
+	result, errs := q.Extract(map[string]Target{
+		"name":    {Path: "name", Kind: KindString},
+		"age":     {Path: "age", Kind: KindInt},
+		"missing": {Path: "nope", Kind: KindString},
+		"wrong":   {Path: "active", Kind: KindInt},
+	})
+
+	if result["name"] != "alice" {
+		t.Fatalf("result[name] = %v, want alice", result["name"])
+	}
+	if result["age"] != int64(40) {
+		t.Fatalf("result[age] = %v, want 40", result["age"])
+	}
+	if _, ok := result["missing"]; ok {
+		t.Fatalf("result[missing] should be absent")
+	}
+	if _, ok := result["wrong"]; ok {
+		t.Fatalf("result[wrong] should be absent")
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("errs = %v, want exactly 2 failures", errs)
+	}
+}
+
+func TestExtractReturnsNilErrorListOnFullSuccess(t *testing.T) {
+	q := mustParse(t, `{"name":"alice"}`)
+	_, errs := q.Extract(map[string]Target{
+		"name": {Path: "name", Kind: KindString},
+	})
+	if errs != nil {
+		t.Fatalf("errs = %v, want nil", errs)
+	}
+}
+
+func TestAccumulateErrorsCollectsAcrossAsXCalls(t *testing.T) {
+	q := mustParse(t, `{"name":"alice"}`)
+	q.AccumulateErrors = true
+
+	_ = q.AsInt("name")
+	_ = q.AsString("missing")
+
+	errs := q.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("Errors() = %v, want 2 recorded errors", errs)
+	}
+
+	q.ResetErrors()
+	if len(q.Errors()) != 0 {
+		t.Fatalf("Errors() after ResetErrors = %v, want empty", q.Errors())
+	}
+}
+
+func TestAccumulateErrorsOffDoesNotRecord(t *testing.T) {
+	q := mustParse(t, `{"name":"alice"}`)
+	_ = q.AsInt("name")
+	if len(q.Errors()) != 0 {
+		t.Fatalf("Errors() = %v, want empty when AccumulateErrors is false", q.Errors())
+	}
+}
@@ -13,6 +13,35 @@ import (
 type JsonQuery struct {
 	blob                    interface{}
 	SingleValuePanicOnError bool
+
+	// AccumulateErrors, when true, makes the AsX accessors record their
+	// error (in addition to returning the same fallback value they return
+	// without it) instead of it simply being discarded by the caller. It has
+	// no effect while SingleValuePanicOnError is set, since the accessors
+	// then panic instead. Use Errors() to retrieve
+	// everything recorded so far. This lets mixed-shape API responses be
+	// pulled apart in one pass and validated afterwards, rather than
+	// stopping at the first missing or mistyped field.
+	AccumulateErrors bool
+	errs             []error
+}
+
+// Errors returns every error recorded by the AsX accessors since
+// AccumulateErrors was enabled (or since the last call to ResetErrors).
+func (j *JsonQuery) Errors() []error {
+	return j.errs
+}
+
+// ResetErrors clears the errors recorded by Errors().
+func (j *JsonQuery) ResetErrors() {
+	j.errs = nil
+}
+
+// recordError appends err to j.errs when AccumulateErrors is enabled.
+func (j *JsonQuery) recordError(err error) {
+	if err != nil && j.AccumulateErrors {
+		j.errs = append(j.errs, err)
+	}
 }
 
 // stringFromInterface converts an interface{} to a string and returns an error if types don't match.
@@ -173,6 +202,7 @@ func (j *JsonQuery) AsBool(s ...string) bool {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 		return false
 	}
 	return val
@@ -194,6 +224,7 @@ func (j *JsonQuery) AsFloat(s ...string) float64 {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 		return 0.0
 	}
 	return val
@@ -225,6 +256,7 @@ func (j *JsonQuery) AsInt(s ...string) int {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -236,6 +268,7 @@ func (j *JsonQuery) AsInt64(s ...string) int64 {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -256,6 +289,7 @@ func (j *JsonQuery) AsString(s ...string) string {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -309,6 +343,7 @@ func (j *JsonQuery) AsObject(s ...string) map[string]interface{} {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -329,6 +364,7 @@ func (j *JsonQuery) AsArray(s ...string) []interface{} {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -349,6 +385,7 @@ func (j *JsonQuery) AsInterface(s ...string) interface{} {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -376,6 +413,7 @@ func (j *JsonQuery) AsArrayOfStrings(s ...string) []string {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -403,6 +441,7 @@ func (j *JsonQuery) AsArrayOfInts(s ...string) []int64 {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -430,6 +469,7 @@ func (j *JsonQuery) AsArrayOfFloats(s ...string) []float64 {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -457,6 +497,7 @@ func (j *JsonQuery) AsArrayOfBools(s ...string) []bool {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -484,6 +525,7 @@ func (j *JsonQuery) AsArrayOfObjects(s ...string) []map[string]interface{} {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -544,6 +586,7 @@ func (j *JsonQuery) AsArrayOfArrays(s ...string) [][]interface{} {
 		if j.SingleValuePanicOnError {
 			panic(err)
 		}
+		j.recordError(err)
 	}
 	return val
 }
@@ -558,6 +601,19 @@ func (j *JsonQuery) AsMatrix2D(s ...string) [][]interface{} {
 	return j.AsArrayOfArrays(s...)
 }
 
+// splitPath normalizes a Get-style argument list into path segments. If a
+// single argument is given that looks like "a.b[2]", it is split on "." and
+// "[]"; otherwise the arguments are used as-is. Shared by rquery and the
+// mutation helpers so both accept the same path syntax.
+func splitPath(s []string) []string {
+	if len(s) == 1 && strings.IndexAny(s[0], ".[]") != -1 {
+		return strings.FieldsFunc(s[0], func(c rune) bool {
+			return c == '.' || c == '[' || c == ']'
+		})
+	}
+	return s
+}
+
 // Recursively query a decoded json blob
 func rquery(blob interface{}, s ...string) (interface{}, error) {
 	var (
@@ -565,14 +621,7 @@ func rquery(blob interface{}, s ...string) (interface{}, error) {
 		err error
 	)
 
-	// If there is only a single string argument and if that single string argument has either a "." or a "[" in it
-	// the assume it is a path specification and disagregate it into an array of indexes.
-	terms := s
-	if len(s) == 1 && strings.IndexAny(s[0], ".[]") != -1 {
-		terms = strings.FieldsFunc(s[0], func(c rune) bool {
-			return c == '.' || c == '[' || c == ']'
-		})
-	}
+	terms := splitPath(s)
 	val = blob
 	for _, q := range terms {
 		val, err = query(val, q)
@@ -0,0 +1,242 @@
+package jsonq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Set writes value at path, creating any missing intermediate objects (or
+// arrays, when the next path segment parses as an integer) along the way.
+// This mirrors the path syntax accepted by Get: either one dotted/bracketed
+// string ("a.b[2]") or a list of individual segments.
+func (j *JsonQuery) Set(value interface{}, path ...string) error {
+	terms := splitPath(path)
+	if len(terms) == 0 {
+		j.blob = value
+		return nil
+	}
+	root, err := setValue(j.blob, terms, value)
+	if err != nil {
+		return err
+	}
+	j.blob = root
+	return nil
+}
+
+// setValue returns container with value written at path, creating
+// container itself (as an object or array, based on path[0]) if it is nil.
+func setValue(container interface{}, path []string, value interface{}) (interface{}, error) {
+	key := path[0]
+	rest := path[1:]
+
+	if idx, err := strconv.Atoi(key); err == nil {
+		arr, ok := container.([]interface{})
+		if !ok {
+			if container != nil {
+				return nil, fmt.Errorf("Cannot set array index %d on non-array %v\n", idx, container)
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("Cannot set negative array index %d\n", idx)
+		}
+		for len(arr) <= idx {
+			arr = append(arr, nil)
+		}
+		if len(rest) == 0 {
+			arr[idx] = value
+			return arr, nil
+		}
+		child, err := setValue(arr[idx], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		arr[idx] = child
+		return arr, nil
+	}
+
+	obj, ok := container.(map[string]interface{})
+	if !ok {
+		if container != nil {
+			return nil, fmt.Errorf("Cannot set field %q on non-object %v\n", key, container)
+		}
+		obj = map[string]interface{}{}
+	}
+	if len(rest) == 0 {
+		obj[key] = value
+		return obj, nil
+	}
+	child, err := setValue(obj[key], rest, value)
+	if err != nil {
+		return nil, err
+	}
+	obj[key] = child
+	return obj, nil
+}
+
+// Delete removes the value at path, shrinking the containing array if the
+// final segment is an index.
+func (j *JsonQuery) Delete(path ...string) error {
+	terms := splitPath(path)
+	if len(terms) == 0 {
+		return fmt.Errorf("Delete requires a non-empty path\n")
+	}
+	root, err := deleteValue(j.blob, terms)
+	if err != nil {
+		return err
+	}
+	j.blob = root
+	return nil
+}
+
+func deleteValue(container interface{}, path []string) (interface{}, error) {
+	key := path[0]
+	rest := path[1:]
+
+	if idx, err := strconv.Atoi(key); err == nil {
+		arr, ok := container.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Cannot delete array index %d on non-array %v\n", idx, container)
+		}
+		if idx < 0 || idx >= len(arr) {
+			return nil, fmt.Errorf("Array index %d out of bounds for delete\n", idx)
+		}
+		if len(rest) == 0 {
+			return append(arr[:idx], arr[idx+1:]...), nil
+		}
+		child, err := deleteValue(arr[idx], rest)
+		if err != nil {
+			return nil, err
+		}
+		arr[idx] = child
+		return arr, nil
+	}
+
+	obj, ok := container.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Cannot delete field %q on non-object %v\n", key, container)
+	}
+	if len(rest) == 0 {
+		delete(obj, key)
+		return obj, nil
+	}
+	child, ok := obj[key]
+	if !ok {
+		return nil, fmt.Errorf("Object does not contain field %s\n", key)
+	}
+	newChild, err := deleteValue(child, rest)
+	if err != nil {
+		return nil, err
+	}
+	obj[key] = newChild
+	return obj, nil
+}
+
+// Append adds value to the end of the array at path, creating the array if
+// it does not exist yet. With no path, it appends to the root blob itself.
+func (j *JsonQuery) Append(value interface{}, path ...string) error {
+	terms := splitPath(path)
+	if len(terms) == 0 {
+		arr, ok := j.blob.([]interface{})
+		if !ok && j.blob != nil {
+			return fmt.Errorf("Cannot append to non-array %v\n", j.blob)
+		}
+		j.blob = append(arr, value)
+		return nil
+	}
+
+	var arr []interface{}
+	if cur, err := rquery(j.blob, terms...); err == nil {
+		a, ok := cur.([]interface{})
+		if !ok {
+			return fmt.Errorf("Cannot append to non-array %v\n", cur)
+		}
+		arr = a
+	}
+
+	root, err := setValue(j.blob, terms, append(arr, value))
+	if err != nil {
+		return err
+	}
+	j.blob = root
+	return nil
+}
+
+// Merge shallow-merges other into the object at path, creating it as an
+// empty object first if necessary. With no path, it merges into the root.
+func (j *JsonQuery) Merge(other map[string]interface{}, path ...string) error {
+	terms := splitPath(path)
+
+	var target map[string]interface{}
+	if len(terms) == 0 {
+		if j.blob == nil {
+			j.blob = map[string]interface{}{}
+		}
+		obj, ok := j.blob.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Cannot merge into non-object %v\n", j.blob)
+		}
+		target = obj
+	} else {
+		cur, err := rquery(j.blob, terms...)
+		if err != nil {
+			cur = map[string]interface{}{}
+		}
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Cannot merge into non-object %v\n", cur)
+		}
+		target = obj
+	}
+
+	for k, v := range other {
+		target[k] = v
+	}
+
+	if len(terms) == 0 {
+		j.blob = target
+		return nil
+	}
+	root, err := setValue(j.blob, terms, target)
+	if err != nil {
+		return err
+	}
+	j.blob = root
+	return nil
+}
+
+// Clone returns a deep copy of j, so mutations on one do not affect the
+// other.
+func (j *JsonQuery) Clone() *JsonQuery {
+	return &JsonQuery{
+		blob:                    deepCopy(j.blob),
+		SingleValuePanicOnError: j.SingleValuePanicOnError,
+		AccumulateErrors:        j.AccumulateErrors,
+		errs:                    append([]error(nil), j.errs...),
+	}
+}
+
+func deepCopy(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = deepCopy(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = deepCopy(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// MarshalJSON implements json.Marshaler, so a JsonQuery built up with Set,
+// Delete, Append and Merge can be serialized back out with encoding/json.
+func (j *JsonQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.blob)
+}
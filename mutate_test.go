@@ -0,0 +1,139 @@
+package jsonq
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSetAutoVivifiesObjectsAndArrays(t *testing.T) {
+	q := NewQuery(map[string]interface{}{})
+
+	if err := q.Set("alice", "users", "0", "name"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	name, err := q.String("users", "0", "name")
+	if err != nil {
+		t.Fatalf("String(users.0.name): %v", err)
+	}
+	if name != "alice" {
+		t.Fatalf("String(users.0.name) = %q, want alice", name)
+	}
+
+	arr, err := q.Array("users")
+	if err != nil || len(arr) != 1 {
+		t.Fatalf("Array(users) = %v, %v, want length 1", arr, err)
+	}
+}
+
+func TestSetOverwritesExistingValue(t *testing.T) {
+	q := mustParse(t, `{"a":{"b":1}}`)
+	if err := q.Set(2, "a", "b"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	v, err := q.Int("a", "b")
+	if err != nil || v != 2 {
+		t.Fatalf("Int(a.b) = %v, %v, want 2", v, err)
+	}
+}
+
+func TestDeleteObjectFieldAndArrayElement(t *testing.T) {
+	q := mustParse(t, `{"a":{"b":1,"c":2},"list":[10,20,30]}`)
+
+	if err := q.Delete("a", "b"); err != nil {
+		t.Fatalf("Delete(a.b): %v", err)
+	}
+	if q.Exists("a", "b") {
+		t.Fatalf("a.b still exists after Delete")
+	}
+	if !q.Exists("a", "c") {
+		t.Fatalf("a.c should still exist")
+	}
+
+	if err := q.Delete("list", "1"); err != nil {
+		t.Fatalf("Delete(list.1): %v", err)
+	}
+	list, err := q.ArrayOfInts("list")
+	if err != nil {
+		t.Fatalf("ArrayOfInts(list): %v", err)
+	}
+	want := []int64{10, 30}
+	if len(list) != len(want) || list[0] != want[0] || list[1] != want[1] {
+		t.Fatalf("list after Delete = %v, want %v", list, want)
+	}
+}
+
+func TestDeleteMissingFieldErrors(t *testing.T) {
+	q := mustParse(t, `{"a":1}`)
+	if err := q.Delete("nope", "deeper"); err == nil {
+		t.Fatalf("Delete(nope.deeper) should have errored: nope does not exist")
+	}
+}
+
+func TestAppendCreatesAndExtendsArray(t *testing.T) {
+	q := mustParse(t, `{"tags":["x"]}`)
+
+	if err := q.Append("y", "tags"); err != nil {
+		t.Fatalf("Append(tags): %v", err)
+	}
+	tags, err := q.ArrayOfStrings("tags")
+	if err != nil || len(tags) != 2 || tags[1] != "y" {
+		t.Fatalf("ArrayOfStrings(tags) = %v, %v", tags, err)
+	}
+
+	if err := q.Append("z", "missing", "path"); err != nil {
+		t.Fatalf("Append(missing.path): %v", err)
+	}
+	created, err := q.ArrayOfStrings("missing", "path")
+	if err != nil || len(created) != 1 || created[0] != "z" {
+		t.Fatalf("ArrayOfStrings(missing.path) = %v, %v", created, err)
+	}
+}
+
+func TestMergeShallowMergesIntoObject(t *testing.T) {
+	q := mustParse(t, `{"meta":{"a":1}}`)
+	if err := q.Merge(map[string]interface{}{"b": 2}, "meta"); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	obj, err := q.Object("meta")
+	if err != nil {
+		t.Fatalf("Object(meta): %v", err)
+	}
+	if obj["a"] != float64(1) || obj["b"] != 2 {
+		t.Fatalf("meta after Merge = %v", obj)
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	q := mustParse(t, `{"a":{"b":1}}`)
+	clone := q.Clone()
+
+	if err := q.Set(2, "a", "b"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	orig, err := clone.Int("a", "b")
+	if err != nil || orig != 1 {
+		t.Fatalf("clone.Int(a.b) = %v, %v, want 1 (unaffected by mutation on q)", orig, err)
+	}
+}
+
+func TestMarshalJSONRoundTrips(t *testing.T) {
+	q := mustParse(t, `{"a":1}`)
+	if err := q.Set("x", "b"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	raw, err := q.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("Unmarshal(MarshalJSON output): %v", err)
+	}
+	if decoded["a"] != float64(1) || decoded["b"] != "x" {
+		t.Fatalf("round-tripped = %v", decoded)
+	}
+}
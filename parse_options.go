@@ -0,0 +1,132 @@
+package jsonq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Options configures how ParseWithOptions decodes JSON into a JsonQuery.
+type Options struct {
+	// UseNumber decodes numbers into json.Number instead of float64,
+	// preserving precision for large integers (e.g. Snowflake-style IDs
+	// or 64-bit monetary values) that would otherwise lose precision
+	// through float64.
+	UseNumber bool
+	// AllowDuplicateKeys controls whether duplicate keys within a single
+	// JSON object are allowed (the default encoding/json behavior, where
+	// the last value wins) or rejected with an error.
+	AllowDuplicateKeys bool
+}
+
+// ParseWithOptions creates a new JsonQuery obj from an io.Reader, like
+// Parse, but lets the caller opt into UseNumber decoding via Options.
+// Duplicate object keys are rejected unless Options.AllowDuplicateKeys is set.
+func ParseWithOptions(r io.Reader, opts Options) (*JsonQuery, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.AllowDuplicateKeys {
+		if err := checkDuplicateKeys(json.NewDecoder(bytes.NewReader(raw))); err != nil {
+			return nil, err
+		}
+	}
+
+	data := map[string]interface{}{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	if opts.UseNumber {
+		d.UseNumber()
+	}
+	if err := d.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	j := new(JsonQuery)
+	j.blob = data
+	j.SingleValuePanicOnError = false
+	return j, nil
+}
+
+// checkDuplicateKeys walks the full token stream looking for any JSON
+// object that defines the same key twice.
+func checkDuplicateKeys(d *json.Decoder) error {
+	return walkValue(d)
+}
+
+// walkValue consumes exactly one JSON value (object, array or scalar) from
+// d, erroring on the first duplicate object key it finds.
+func walkValue(d *json.Decoder) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		seen := map[string]bool{}
+		for d.More() {
+			keyTok, err := d.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := keyTok.(string)
+			if seen[key] {
+				return fmt.Errorf("duplicate key %q in JSON object\n", key)
+			}
+			seen[key] = true
+			if err := walkValue(d); err != nil {
+				return err
+			}
+		}
+		_, err := d.Token() // consume '}'
+		return err
+	case '[':
+		for d.More() {
+			if err := walkValue(d); err != nil {
+				return err
+			}
+		}
+		_, err := d.Token() // consume ']'
+		return err
+	}
+	return nil
+}
+
+// Number extracts a json.Number from the JsonQuery, preserving precision
+// for values that do not fit safely in a float64. It works whether the
+// query was parsed with Options.UseNumber or not, falling back to
+// formatting a plain float64 as a json.Number.
+func (j *JsonQuery) Number(s ...string) (json.Number, error) {
+	val, err := rquery(j.blob, s...)
+	if err != nil {
+		return "", err
+	}
+	switch v := val.(type) {
+	case json.Number:
+		return v, nil
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	}
+	return "", fmt.Errorf("Expected numeric value for Number, got \"%v\"\n", val)
+}
+
+// AsNumber extracts a json.Number from the JsonQuery, but panics on error
+// so it can be used inline.
+func (j *JsonQuery) AsNumber(s ...string) json.Number {
+	val, err := j.Number(s...)
+	if err != nil {
+		if j.SingleValuePanicOnError {
+			panic(err)
+		}
+		j.recordError(err)
+	}
+	return val
+}
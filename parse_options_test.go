@@ -0,0 +1,61 @@
+package jsonq
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWithOptionsUseNumberPreservesPrecision(t *testing.T) {
+	q, err := ParseWithOptions(strings.NewReader(`{"id":9007199254740993}`), Options{UseNumber: true})
+	if err != nil {
+		t.Fatalf("ParseWithOptions: %v", err)
+	}
+	n, err := q.Number("id")
+	if err != nil {
+		t.Fatalf("Number(id): %v", err)
+	}
+	if n.String() != "9007199254740993" {
+		t.Fatalf("Number(id) = %s, want 9007199254740993 (no float64 precision loss)", n.String())
+	}
+}
+
+func TestParseWithOptionsRejectsDuplicateKeysByDefault(t *testing.T) {
+	_, err := ParseWithOptions(strings.NewReader(`{"a":1,"a":2}`), Options{})
+	if err == nil {
+		t.Fatalf("expected error for duplicate key, got nil")
+	}
+}
+
+func TestParseWithOptionsAllowsDuplicateKeysWhenOptedIn(t *testing.T) {
+	q, err := ParseWithOptions(strings.NewReader(`{"a":1,"a":2}`), Options{AllowDuplicateKeys: true})
+	if err != nil {
+		t.Fatalf("ParseWithOptions: %v", err)
+	}
+	v, err := q.Int("a")
+	if err != nil || v != 2 {
+		t.Fatalf("Int(a) = %v, %v, want 2 (last value wins)", v, err)
+	}
+}
+
+func TestNumberFallsBackToFloat64(t *testing.T) {
+	q := mustParse(t, `{"price":9.5}`)
+	n, err := q.Number("price")
+	if err != nil {
+		t.Fatalf("Number(price): %v", err)
+	}
+	if n.String() != "9.5" {
+		t.Fatalf("Number(price) = %s, want 9.5", n.String())
+	}
+}
+
+func TestAsNumberRecordsErrorUnderAccumulateErrors(t *testing.T) {
+	q := mustParse(t, `{"name":"alice"}`)
+	q.AccumulateErrors = true
+
+	_ = q.AsNumber("name")
+
+	errs := q.Errors()
+	if len(errs) != 1 {
+		t.Fatalf("Errors() = %v, want exactly one recorded error from AsNumber", errs)
+	}
+}
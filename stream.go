@@ -0,0 +1,188 @@
+package jsonq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// StreamQuery navigates a large JSON document lazily using the token
+// stream from json.Decoder, decoding only the subtree actually requested
+// instead of materializing the whole document into a map[string]interface{}
+// up front. It trades the ergonomics of JsonQuery for the ability to
+// handle multi-gigabyte documents or newline-delimited JSON.
+type StreamQuery struct {
+	dec *json.Decoder
+	err error
+}
+
+// NewStreamQuery creates a StreamQuery reading tokens from r.
+func NewStreamQuery(r io.Reader) *StreamQuery {
+	return &StreamQuery{dec: json.NewDecoder(r)}
+}
+
+// Err returns the first error encountered while navigating the stream, if
+// any. Field, Index and Iterate all short-circuit once it is set.
+func (s *StreamQuery) Err() error {
+	return s.err
+}
+
+// Field skips tokens until it finds name as a key of the current JSON
+// object, then returns a StreamQuery positioned at that field's value.
+func (s *StreamQuery) Field(name string) *StreamQuery {
+	if s.err != nil {
+		return s
+	}
+	tok, err := s.dec.Token()
+	if err != nil {
+		return &StreamQuery{dec: s.dec, err: err}
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != '{' {
+		return &StreamQuery{dec: s.dec, err: fmt.Errorf("Field(%q): expected object, got %v\n", name, tok)}
+	}
+	for s.dec.More() {
+		keyTok, err := s.dec.Token()
+		if err != nil {
+			return &StreamQuery{dec: s.dec, err: err}
+		}
+		key, _ := keyTok.(string)
+		if key == name {
+			return &StreamQuery{dec: s.dec}
+		}
+		if err := skipValue(s.dec); err != nil {
+			return &StreamQuery{dec: s.dec, err: err}
+		}
+	}
+	return &StreamQuery{dec: s.dec, err: fmt.Errorf("Field(%q): not found\n", name)}
+}
+
+// Index skips tokens until it reaches element i of the current JSON
+// array, then returns a StreamQuery positioned at that element.
+func (s *StreamQuery) Index(i int) *StreamQuery {
+	if s.err != nil {
+		return s
+	}
+	tok, err := s.dec.Token()
+	if err != nil {
+		return &StreamQuery{dec: s.dec, err: err}
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != '[' {
+		return &StreamQuery{dec: s.dec, err: fmt.Errorf("Index(%d): expected array, got %v\n", i, tok)}
+	}
+	for idx := 0; s.dec.More(); idx++ {
+		if idx == i {
+			return &StreamQuery{dec: s.dec}
+		}
+		if err := skipValue(s.dec); err != nil {
+			return &StreamQuery{dec: s.dec, err: err}
+		}
+	}
+	return &StreamQuery{dec: s.dec, err: fmt.Errorf("Index(%d): array index out of bounds\n", i)}
+}
+
+// navigate walks a dotted/bracketed path (the same syntax Get accepts),
+// alternating Field and Index depending on whether each segment parses as
+// an integer.
+func (s *StreamQuery) navigate(path []string) *StreamQuery {
+	cur := s
+	for _, term := range splitPath(path) {
+		if idx, err := strconv.Atoi(term); err == nil {
+			cur = cur.Index(idx)
+		} else {
+			cur = cur.Field(term)
+		}
+	}
+	return cur
+}
+
+// Decode decodes the value at the current position into v, the same way
+// json.Decoder.Decode would.
+func (s *StreamQuery) Decode(v interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.dec.Decode(v)
+}
+
+// Query decodes the value at the current position into a *JsonQuery for
+// further, non-streaming navigation.
+func (s *StreamQuery) Query() (*JsonQuery, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	var data interface{}
+	if err := s.dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	return NewQuery(data), nil
+}
+
+// Iterate navigates to the array at path (the same dotted/bracketed syntax
+// Get accepts) and streams over its elements, invoking fn with a
+// *JsonQuery for each one without ever holding the full slice in memory.
+// Iteration stops at the first error returned by fn or hit while
+// decoding.
+func (s *StreamQuery) Iterate(fn func(*JsonQuery) error, path ...string) error {
+	target := s.navigate(path)
+	if target.err != nil {
+		return target.err
+	}
+
+	tok, err := target.dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != '[' {
+		return fmt.Errorf("Iterate: expected array, got %v\n", tok)
+	}
+	for target.dec.More() {
+		var data interface{}
+		if err := target.dec.Decode(&data); err != nil {
+			return err
+		}
+		if err := fn(NewQuery(data)); err != nil {
+			return err
+		}
+	}
+	_, err = target.dec.Token() // consume ']'
+	return err
+}
+
+// skipValue consumes exactly one JSON value from dec without decoding it;
+// used to skip past fields and elements that were not selected.
+func skipValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+	switch delim {
+	case '{':
+		for dec.More() {
+			if _, err := dec.Token(); err != nil { // key
+				return err
+			}
+			if err := skipValue(dec); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token() // consume '}'
+		return err
+	case '[':
+		for dec.More() {
+			if err := skipValue(dec); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token() // consume ']'
+		return err
+	}
+	return nil
+}
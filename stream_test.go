@@ -0,0 +1,82 @@
+package jsonq
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStreamQueryFieldAndDecode(t *testing.T) {
+	sq := NewStreamQuery(strings.NewReader(`{"a":{"b":42}}`))
+	var b int
+	if err := sq.Field("a").Field("b").Decode(&b); err != nil {
+		t.Fatalf("Field(a).Field(b).Decode: %v", err)
+	}
+	if b != 42 {
+		t.Fatalf("b = %d, want 42", b)
+	}
+}
+
+func TestStreamQueryIndex(t *testing.T) {
+	sq := NewStreamQuery(strings.NewReader(`[10,20,30]`))
+	var v int
+	if err := sq.Index(1).Decode(&v); err != nil {
+		t.Fatalf("Index(1).Decode: %v", err)
+	}
+	if v != 20 {
+		t.Fatalf("v = %d, want 20", v)
+	}
+}
+
+func TestStreamQueryFieldNotFoundErrors(t *testing.T) {
+	sq := NewStreamQuery(strings.NewReader(`{"a":1}`))
+	res := sq.Field("missing")
+	if res.Err() == nil {
+		t.Fatalf("Field(missing) should set Err()")
+	}
+}
+
+func TestStreamQueryIterateSkipsUnselectedSiblings(t *testing.T) {
+	doc := `{"meta":{"total":2},"users":[{"name":"alice"},{"name":"bob"}]}`
+	sq := NewStreamQuery(strings.NewReader(doc))
+
+	var names []string
+	err := sq.Iterate(func(item *JsonQuery) error {
+		names = append(names, item.AsString("name"))
+		return nil
+	}, "users")
+	if err != nil {
+		t.Fatalf("Iterate(users): %v", err)
+	}
+	if len(names) != 2 || names[0] != "alice" || names[1] != "bob" {
+		t.Fatalf("names = %v, want [alice bob]", names)
+	}
+}
+
+func TestStreamQueryIterateStopsOnCallbackError(t *testing.T) {
+	sq := NewStreamQuery(strings.NewReader(`[1,2,3]`))
+	count := 0
+	stopErr := errors.New("stop after first element")
+	err := sq.Iterate(func(item *JsonQuery) error {
+		count++
+		return stopErr
+	})
+	if err != stopErr {
+		t.Fatalf("Iterate error = %v, want %v", err, stopErr)
+	}
+	if count != 1 {
+		t.Fatalf("callback invoked %d times, want 1", count)
+	}
+}
+
+func TestStreamQueryQueryDecodesSubtreeForFurtherNavigation(t *testing.T) {
+	sq := NewStreamQuery(strings.NewReader(`{"a":{"b":1,"c":2}}`))
+	nested, err := sq.Field("a").Query()
+	if err != nil {
+		t.Fatalf("Query(): %v", err)
+	}
+	v, err := nested.Int("c")
+	if err != nil || v != 2 {
+		t.Fatalf("nested.Int(c) = %v, %v, want 2", v, err)
+	}
+}
@@ -0,0 +1,177 @@
+package jsonq
+
+import "fmt"
+
+// rqueryAll evaluates a path against blob the same way rquery does, except
+// it also understands "*" (fan out one level) and "**" (recursive descent
+// through every nested container) segments. It carries a frontier of
+// matching values through the walk instead of a single value, returning
+// every match instead of just one. Unlike rquery, a null value at the end
+// of the path is returned as a match rather than reported as an error.
+func rqueryAll(blob interface{}, s ...string) ([]interface{}, error) {
+	terms := splitPath(s)
+	frontier := []interface{}{blob}
+	fanned := false
+	for _, term := range terms {
+		next, err := queryAll(frontier, term, fanned)
+		if err != nil {
+			return nil, err
+		}
+		frontier = next
+		if term == "*" || term == "**" {
+			fanned = true
+		}
+	}
+	return frontier, nil
+}
+
+// queryAll applies a single path segment to every value in frontier,
+// producing the next frontier. fanned records whether a "*"/"**" segment
+// has already fired earlier in the path, not whether frontier currently
+// happens to hold one element — a fan-out can easily narrow back down to
+// a single match (e.g. "items.*.name" on a one-element "items" array).
+func queryAll(frontier []interface{}, term string, fanned bool) ([]interface{}, error) {
+	switch term {
+	case "*":
+		var out []interface{}
+		for _, val := range frontier {
+			children, err := childrenOf(val)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, children...)
+		}
+		return out, nil
+	case "**":
+		var out []interface{}
+		for _, val := range frontier {
+			collectRecursive(val, &out)
+		}
+		return out, nil
+	default:
+		// A plain segment applied before any "*"/"**" has fired behaves
+		// exactly like rquery: a lookup failure is an error. Once a
+		// wildcard has fanned out the frontier, though, most of it won't
+		// have a given field (that's the point of "**.foo" over a
+		// heterogeneous tree), so mismatches are skipped instead.
+		if !fanned {
+			res, err := query(frontier[0], term)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{res}, nil
+		}
+		out := make([]interface{}, 0, len(frontier))
+		for _, val := range frontier {
+			res, err := query(val, term)
+			if err == nil {
+				out = append(out, res)
+			}
+		}
+		return out, nil
+	}
+}
+
+// childrenOf returns every element of an array, or every value of an
+// object, for "*" to fan out over.
+func childrenOf(val interface{}) ([]interface{}, error) {
+	switch v := val.(type) {
+	case []interface{}:
+		return v, nil
+	case map[string]interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			out = append(out, item)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("Cannot fan out \"*\" on non-container %v\n", val)
+}
+
+// GetAll extracts every value matching path, which may use "*" (fan out
+// one level) and "**" (recursive descent) segments in addition to the
+// plain dotted/bracketed syntax Get accepts, e.g. "items.*.name" or
+// "**.error_code".
+func (j *JsonQuery) GetAll(path ...string) ([]interface{}, error) {
+	return rqueryAll(j.blob, path...)
+}
+
+// AllStrings extracts every string value matching path.
+func (j *JsonQuery) AllStrings(path ...string) ([]string, error) {
+	vals, err := j.GetAll(path...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(vals))
+	for i, val := range vals {
+		out[i], err = stringFromInterface(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// AllInts extracts every int value matching path.
+func (j *JsonQuery) AllInts(path ...string) ([]int64, error) {
+	vals, err := j.GetAll(path...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int64, len(vals))
+	for i, val := range vals {
+		out[i], err = intFromInterface(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// AllFloats extracts every float64 value matching path.
+func (j *JsonQuery) AllFloats(path ...string) ([]float64, error) {
+	vals, err := j.GetAll(path...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(vals))
+	for i, val := range vals {
+		out[i], err = floatFromInterface(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// AllBools extracts every bool value matching path.
+func (j *JsonQuery) AllBools(path ...string) ([]bool, error) {
+	vals, err := j.GetAll(path...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]bool, len(vals))
+	for i, val := range vals {
+		out[i], err = boolFromInterface(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// AllObjects extracts every json object matching path.
+func (j *JsonQuery) AllObjects(path ...string) ([]map[string]interface{}, error) {
+	vals, err := j.GetAll(path...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]map[string]interface{}, len(vals))
+	for i, val := range vals {
+		out[i], err = objectFromInterface(val)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
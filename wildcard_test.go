@@ -0,0 +1,76 @@
+package jsonq
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestGetAllWildcardFansOutOneLevel(t *testing.T) {
+	q := mustParse(t, `{"items":[{"name":"a"},{"name":"b"},{"name":"c"}]}`)
+
+	names, err := q.AllStrings("items.*.name")
+	if err != nil {
+		t.Fatalf("AllStrings(items.*.name): %v", err)
+	}
+	want := []string{"a", "b", "c"}
+	if len(names) != len(want) {
+		t.Fatalf("AllStrings(items.*.name) = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("AllStrings(items.*.name) = %v, want %v", names, want)
+		}
+	}
+}
+
+func TestGetAllRecursiveDescentSkipsNonMatchingBranches(t *testing.T) {
+	q := mustParse(t, `{"meta":{"error_code":"E1"},"users":[{"name":"alice"}],"tags":["x"]}`)
+
+	codes, err := q.AllStrings("**.error_code")
+	if err != nil {
+		t.Fatalf("AllStrings(**.error_code): %v", err)
+	}
+	if len(codes) != 1 || codes[0] != "E1" {
+		t.Fatalf("AllStrings(**.error_code) = %v, want [E1]", codes)
+	}
+}
+
+func TestGetAllWildcardOnSingleElementArrayDoesNotErrorOnMismatch(t *testing.T) {
+	// Regression test: a fan-out that happens to narrow to exactly one
+	// element used to be mistaken for "no wildcard fired yet" and would
+	// error instead of skipping the missing field.
+	q := mustParse(t, `{"items":[{"x":1}]}`)
+
+	vals, err := q.GetAll("items", "*", "name")
+	if err != nil {
+		t.Fatalf("GetAll(items.*.name) = %v, %v, want empty result with no error", vals, err)
+	}
+	if len(vals) != 0 {
+		t.Fatalf("GetAll(items.*.name) = %v, want no matches", vals)
+	}
+}
+
+func TestGetAllWildcardOnNonContainerErrors(t *testing.T) {
+	q := mustParse(t, `{"a":1}`)
+	if _, err := q.GetAll("a", "*"); err == nil {
+		t.Fatalf("GetAll(a.*) on a scalar should error")
+	}
+}
+
+func TestAllIntsAndAllObjects(t *testing.T) {
+	q := mustParse(t, `{"items":[{"n":1},{"n":2},{"n":3}]}`)
+
+	nums, err := q.AllInts("items.*.n")
+	if err != nil {
+		t.Fatalf("AllInts(items.*.n): %v", err)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	if len(nums) != 3 || nums[0] != 1 || nums[1] != 2 || nums[2] != 3 {
+		t.Fatalf("AllInts(items.*.n) = %v", nums)
+	}
+
+	objs, err := q.AllObjects("items.*")
+	if err != nil || len(objs) != 3 {
+		t.Fatalf("AllObjects(items.*) = %v, %v", objs, err)
+	}
+}